@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cacheEntry is one record in the on-disk index: a path plus the stat
+// data needed to decide whether it has changed since the last run. For
+// directories, size is the recursive total of everything beneath it.
+type cacheEntry struct {
+	path  string
+	size  int64
+	isDir bool
+	mtime time.Time
+}
+
+// indexStats tracks how much of a refresh reused cached data versus
+// re-stating the filesystem, reported by --index-stats.
+type indexStats struct {
+	hits   int
+	misses int
+}
+
+func indexPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ls", "index.db"), nil
+}
+
+// loadIndex reads the on-disk index into a path -> cacheEntry map. A
+// missing index file is not an error; it just means an empty cache.
+func loadIndex() (map[string]cacheEntry, error) {
+	path, err := indexPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]cacheEntry{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	idx := make(map[string]cacheEntry)
+	r := bufio.NewReader(f)
+	for {
+		var plen uint32
+		if err := binary.Read(r, binary.BigEndian, &plen); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		buf := make([]byte, plen)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		var isDirByte uint8
+		var size int64
+		var mtimeNano int64
+		if err := binary.Read(r, binary.BigEndian, &isDirByte); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.BigEndian, &mtimeNano); err != nil {
+			return nil, err
+		}
+
+		p := string(buf)
+		idx[p] = cacheEntry{
+			path:  p,
+			size:  size,
+			isDir: isDirByte == 1,
+			mtime: time.Unix(0, mtimeNano),
+		}
+	}
+	return idx, nil
+}
+
+// saveIndex writes idx back to disk as a flat list sorted by path. A
+// --find query does not binary-search this order: findInIndex scans
+// every entry.
+func saveIndex(idx map[string]cacheEntry) error {
+	path, err := indexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	entries := make([]cacheEntry, 0, len(idx))
+	for _, e := range idx {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, e := range entries {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(e.path))); err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := w.WriteString(e.path); err != nil {
+			f.Close()
+			return err
+		}
+		isDirByte := uint8(0)
+		if e.isDir {
+			isDirByte = 1
+		}
+		if err := binary.Write(w, binary.BigEndian, isDirByte); err != nil {
+			f.Close()
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, e.size); err != nil {
+			f.Close()
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, e.mtime.UnixNano()); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// refreshSubtree brings idx up to date for path, reusing a cached file's
+// entry whenever its own mtime on disk still matches what was last
+// recorded. A directory's mtime only changes when a direct entry is
+// added, removed, or renamed — it says nothing about content changes
+// further down, so directories can never be trusted on their own mtime
+// alone; refreshSubtree always recurses into a directory's children and
+// lets their own cache checks (recursively, down to the files) decide
+// what's actually stale.
+func refreshSubtree(idx map[string]cacheEntry, path string, info os.FileInfo, stats *indexStats) (cacheEntry, error) {
+	if !info.IsDir() {
+		if cached, ok := idx[path]; ok && !cached.isDir && cached.mtime.Equal(info.ModTime()) {
+			stats.hits++
+			return cached, nil
+		}
+		stats.misses++
+		ce := cacheEntry{path: path, size: info.Size(), mtime: info.ModTime()}
+		idx[path] = ce
+		return ce, nil
+	}
+
+	raw, err := os.ReadDir(path)
+	if err != nil {
+		return cacheEntry{}, err
+	}
+
+	var total int64
+	for _, e := range raw {
+		child := filepath.Join(path, e.Name())
+		cinfo, err := e.Info()
+		if err != nil {
+			continue
+		}
+		ce, err := refreshSubtree(idx, child, cinfo, stats)
+		if err != nil {
+			continue
+		}
+		total += ce.size
+	}
+
+	ce := cacheEntry{path: path, isDir: true, size: total, mtime: info.ModTime()}
+	if cached, ok := idx[path]; ok && cached.isDir && cached.mtime.Equal(info.ModTime()) && cached.size == total {
+		stats.hits++
+	} else {
+		stats.misses++
+	}
+	idx[path] = ce
+	return ce, nil
+}
+
+// indexDirSizes refreshes the index for each directory entry in items
+// (resolved relative to target) and returns a name -> recursive size map
+// for the ones it could stat. It persists the updated index as a side
+// effect, best-effort: a failure to save does not prevent the sizes from
+// being returned.
+func indexDirSizes(target string, items []entry) (map[string]int64, error) {
+	idx, err := loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &indexStats{}
+	sizes := make(map[string]int64)
+	for _, it := range items {
+		if !it.isDir {
+			continue
+		}
+		full, err := filepath.Abs(filepath.Join(target, it.name))
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(full)
+		if err != nil {
+			continue
+		}
+		ce, err := refreshSubtree(idx, full, info, stats)
+		if err != nil {
+			continue
+		}
+		sizes[it.name] = ce.size
+	}
+
+	_ = saveIndex(idx)
+	return sizes, nil
+}
+
+// reindex wipes the index and rebuilds it from scratch for target.
+func reindex(target string) (indexStats, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return indexStats{}, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return indexStats{}, err
+	}
+
+	idx := make(map[string]cacheEntry)
+	stats := &indexStats{}
+	if _, err := refreshSubtree(idx, abs, info, stats); err != nil {
+		return indexStats{}, err
+	}
+	if err := saveIndex(idx); err != nil {
+		return indexStats{}, err
+	}
+	return *stats, nil
+}
+
+// findInIndex returns every indexed path whose base name matches pattern,
+// which may use glob syntax, or whose full path contains pattern as a
+// literal substring. Entries are sorted paths, but since pattern is
+// matched against each entry's base name rather than its full path, a
+// prefix binary search on the stored paths can't narrow the range (an
+// absolute path's prefix bears no relation to its base name) — this
+// dataset is small enough that a plain linear scan is the right call.
+func findInIndex(idx map[string]cacheEntry, pattern string) []cacheEntry {
+	entries := make([]cacheEntry, 0, len(idx))
+	for _, e := range idx {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+
+	var matches []cacheEntry
+	for _, e := range entries {
+		if ok, err := filepath.Match(pattern, filepath.Base(e.path)); err == nil && ok {
+			matches = append(matches, e)
+			continue
+		}
+		if strings.Contains(e.path, pattern) {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
+
+func runFind(pattern string) error {
+	idx, err := loadIndex()
+	if err != nil {
+		return err
+	}
+
+	matches := findInIndex(idx, pattern)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].path < matches[j].path })
+
+	if len(matches) == 0 {
+		fmt.Println(countStyle.Render("  no matches in index"))
+		return nil
+	}
+
+	for _, m := range matches {
+		tag := fileTag
+		sizeStr := ""
+		if m.isDir {
+			tag = dirTag
+			sizeStr = sizeDashStyle.Render("—")
+		} else {
+			num, unit := humanSizeParts(m.size)
+			sizeStr = sizeNumStyle.Render(num) + sizeUnitStyle.Render(unit)
+		}
+		fmt.Println("  " + pad(tag, 4) + "  " + m.path + "  " + sizeStr)
+	}
+	return nil
+}
+
+func runReindex(target string) error {
+	stats, err := reindex(target)
+	if err != nil {
+		return err
+	}
+	fmt.Println(countStyle.Render(fmt.Sprintf("  reindexed %d entries", stats.hits+stats.misses)))
+	return nil
+}
+
+func runIndexStats(target string) error {
+	idx, err := loadIndex()
+	if err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return err
+	}
+
+	stats := &indexStats{}
+	if _, err := refreshSubtree(idx, abs, info, stats); err != nil {
+		return err
+	}
+	if err := saveIndex(idx); err != nil {
+		return err
+	}
+
+	path, err := indexPath()
+	if err != nil {
+		return err
+	}
+	var onDisk int64
+	if fi, err := os.Stat(path); err == nil {
+		onDisk = fi.Size()
+	}
+
+	hitRate := 0.0
+	if total := stats.hits + stats.misses; total > 0 {
+		hitRate = float64(stats.hits) / float64(total) * 100
+	}
+
+	fmt.Println(countStyle.Render(fmt.Sprintf("  index:     %s", path)))
+	fmt.Println(countStyle.Render(fmt.Sprintf("  entries:   %d", len(idx))))
+	fmt.Println(countStyle.Render(fmt.Sprintf("  on disk:   %d bytes", onDisk)))
+	fmt.Println(countStyle.Render(fmt.Sprintf("  hit rate:  %.1f%% (%d hits, %d misses)", hitRate, stats.hits, stats.misses)))
+	return nil
+}
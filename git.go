@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type gitStatus int
+
+const (
+	gitNone gitStatus = iota
+	gitUntracked
+	gitModified
+	gitStaged
+	gitIgnored
+)
+
+var (
+	gitUntrackedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff9900"))
+	gitModifiedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffcc00"))
+	gitStagedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ccff"))
+	gitIgnoredStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+)
+
+// glyph renders the single-character status tag shown in the GIT column.
+func (s gitStatus) glyph() string {
+	switch s {
+	case gitUntracked:
+		return gitUntrackedStyle.Render("?")
+	case gitModified:
+		return gitModifiedStyle.Render("M")
+	case gitStaged:
+		return gitStagedStyle.Render("+")
+	case gitIgnored:
+		return gitIgnoredStyle.Render("!")
+	default:
+		return " "
+	}
+}
+
+// gitStatuses shells out to git once and returns a map from repo-root-
+// relative path to status, plus the repo root itself. If target isn't
+// inside a Git working tree it returns an empty map and no error.
+func gitStatuses(target string) (statuses map[string]gitStatus, repoRoot string, err error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rootOut, err := exec.Command("git", "-C", abs, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return map[string]gitStatus{}, "", nil
+	}
+	repoRoot = strings.TrimSpace(string(rootOut))
+
+	raw, err := exec.Command("git", "-C", abs, "status", "--porcelain=v1", "-z", "--ignored").Output()
+	if err != nil {
+		return nil, "", err
+	}
+
+	statuses = make(map[string]gitStatus)
+	records := strings.Split(string(raw), "\x00")
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if len(rec) < 3 {
+			continue
+		}
+		code := rec[:2]
+		path := rec[3:]
+
+		// Renames/copies carry the original path as a second NUL-terminated
+		// field; skip over it rather than mistaking it for the next entry.
+		if code[0] == 'R' || code[0] == 'C' {
+			i++
+		}
+
+		statuses[path] = parseGitCode(code)
+	}
+	return statuses, repoRoot, nil
+}
+
+func parseGitCode(code string) gitStatus {
+	switch {
+	case code == "??":
+		return gitUntracked
+	case code == "!!":
+		return gitIgnored
+	case code[0] != ' ' && code[0] != '?':
+		return gitStaged
+	case code[1] != ' ':
+		return gitModified
+	default:
+		return gitNone
+	}
+}
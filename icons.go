@@ -0,0 +1,90 @@
+package main
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+//go:embed icons_default.toml
+var defaultIconsTOML string
+
+// iconConfig maps filenames and extensions to a Nerd Font glyph. It is
+// seeded from the embedded default and layered with the user's
+// ~/.config/ls/icons.toml, if present, so users can add or override
+// entries without recompiling.
+type iconConfig struct {
+	DefaultFile string            `toml:"default_file"`
+	DefaultDir  string            `toml:"default_dir"`
+	Extensions  map[string]string `toml:"extensions"`
+	Names       map[string]string `toml:"names"`
+}
+
+func loadIconConfig() (iconConfig, error) {
+	var cfg iconConfig
+	if _, err := toml.Decode(defaultIconsTOML, &cfg); err != nil {
+		return cfg, err
+	}
+
+	path, err := userIconsConfigPath()
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, nil
+	}
+
+	var user iconConfig
+	if _, err := toml.Decode(string(data), &user); err != nil {
+		return cfg, nil
+	}
+
+	for ext, glyph := range user.Extensions {
+		if cfg.Extensions == nil {
+			cfg.Extensions = map[string]string{}
+		}
+		cfg.Extensions[ext] = glyph
+	}
+	for name, glyph := range user.Names {
+		if cfg.Names == nil {
+			cfg.Names = map[string]string{}
+		}
+		cfg.Names[name] = glyph
+	}
+	if user.DefaultFile != "" {
+		cfg.DefaultFile = user.DefaultFile
+	}
+	if user.DefaultDir != "" {
+		cfg.DefaultDir = user.DefaultDir
+	}
+
+	return cfg, nil
+}
+
+func userIconsConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ls", "icons.toml"), nil
+}
+
+// iconFor returns the glyph for it: an exact filename match wins, then
+// the extension, then the directory/file default.
+func (c iconConfig) iconFor(it entry) string {
+	if g, ok := c.Names[it.name]; ok {
+		return g
+	}
+	if it.isDir {
+		return c.DefaultDir
+	}
+	if g, ok := c.Extensions[strings.ToLower(it.ext)]; ok {
+		return g
+	}
+	return c.DefaultFile
+}
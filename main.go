@@ -6,7 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -33,6 +36,9 @@ var (
 	sizeUnitStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#005c2e"))
 	sizeDashStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#003d1a"))
 
+	// Mtime
+	mtimeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00994d"))
+
 	// Error
 	errStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff3334"))
 
@@ -40,98 +46,197 @@ var (
 	countStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#005c2e"))
 )
 
+const mtimeWidth = 16 // "2006-01-02 15:04"
+
+func formatMtime(t time.Time) string {
+	return t.Format("2006-01-02 15:04")
+}
+
 type entry struct {
-	name  string
-	isDir bool
-	isSym bool
-	size  int64
-	dot   bool
-	ext   string
+	name     string
+	isDir    bool
+	isSym    bool
+	size     int64
+	dot      bool
+	ext      string
+	mtime    time.Time
+	selected bool
 }
 
 func main() {
 	showAll := false
 	filesOnly := false
+	interactive := false
+	tree := false
+	dirsOnly := false
+	maxLevel := -1
+	var excludes []string
+	doReindex := false
+	doIndexStats := false
+	findPattern := ""
+	longFormat := false
+	reverse := false
+	sortKey := ""
+	showIcons := false
+	showGit := false
 	target := "."
 
-	for _, arg := range os.Args[1:] {
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		switch arg {
 		case "-a", "--all":
 			showAll = true
 		case "-f", "--files":
 			filesOnly = true
+		case "-i", "--interactive":
+			interactive = true
+		case "-t", "--tree":
+			tree = true
+		case "-d", "--dirs-only":
+			dirsOnly = true
+		case "-L", "--level":
+			i++
+			if i < len(args) {
+				n, err := strconv.Atoi(args[i])
+				if err != nil {
+					fmt.Fprintln(os.Stderr, errStyle.Render("  error: invalid --level: "+args[i]))
+					os.Exit(1)
+				}
+				maxLevel = n
+			}
+		case "-I":
+			i++
+			if i < len(args) {
+				excludes = append(excludes, args[i])
+			}
+		case "--reindex":
+			doReindex = true
+		case "--index-stats":
+			doIndexStats = true
+		case "--find":
+			i++
+			if i < len(args) {
+				findPattern = args[i]
+			}
+		case "-l", "--long":
+			longFormat = true
+		case "-r", "--reverse":
+			reverse = true
+		case "-S":
+			i++
+			if i < len(args) {
+				sortKey = args[i]
+			}
+		case "--sort":
+			i++
+			if i < len(args) {
+				sortKey = args[i]
+			}
+		case "--icons":
+			showIcons = true
+		case "--git":
+			showGit = true
 		case "-h", "--help":
 			fmt.Println("Usage: ls [options] [path]")
-			fmt.Println("  -a, --all     show hidden files")
-			fmt.Println("  -f, --files   files only")
-			fmt.Println("  -h, --help    this message")
+			fmt.Println("  -a, --all           show hidden files")
+			fmt.Println("  -f, --files         files only")
+			fmt.Println("  -i, --interactive   browse with the keyboard, print the selected path on exit")
+			fmt.Println("  -t, --tree          render contents recursively as a tree")
+			fmt.Println("  -L, --level N       max recursion depth for --tree")
+			fmt.Println("  -d, --dirs-only     tree: list directories only")
+			fmt.Println("  -I pattern          tree: exclude names matching a gitignore-style pattern")
+			fmt.Println("  --find PATTERN      query the index instead of the filesystem")
+			fmt.Println("  --reindex           rebuild the on-disk index from scratch")
+			fmt.Println("  --index-stats       print index size and hit rate")
+			fmt.Println("  -l, --long          show the MTIME column")
+			fmt.Println("  -S, --sort=KEY      sort by name|size|ext|mtime|type")
+			fmt.Println("  -r, --reverse       reverse the sort order")
+			fmt.Println("  --icons             prefix names with a Nerd Font glyph")
+			fmt.Println("  --git               shade names by Git status")
+			fmt.Println("  -h, --help          this message")
 			return
 		default:
-			if !strings.HasPrefix(arg, "-") {
+			if strings.HasPrefix(arg, "--sort=") {
+				sortKey = strings.TrimPrefix(arg, "--sort=")
+			} else if !strings.HasPrefix(arg, "-") {
 				target = arg
 			}
 		}
 	}
 
-	entries, err := os.ReadDir(target)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
-		os.Exit(1)
+	if findPattern != "" {
+		if err := runFind(findPattern); err != nil {
+			fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+			os.Exit(1)
+		}
+		return
 	}
 
-	var items []entry
-	for _, e := range entries {
-		name := e.Name()
-		isDot := strings.HasPrefix(name, ".")
+	if doReindex {
+		if err := runReindex(target); err != nil {
+			fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
 
-		if isDot && !showAll {
-			continue
+	if doIndexStats {
+		if err := runIndexStats(target); err != nil {
+			fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+			os.Exit(1)
 		}
+		return
+	}
 
-		info, err := e.Info()
+	if interactive {
+		path, err := runInteractive(target, showAll, filesOnly)
 		if err != nil {
-			continue
+			fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+			os.Exit(1)
 		}
-
-		isDir := e.IsDir()
-		isSym := e.Type()&os.ModeSymlink != 0
-
-		if isSym {
-			resolved, err := filepath.EvalSymlinks(filepath.Join(target, name))
-			if err == nil {
-				ri, err := os.Stat(resolved)
-				if err == nil {
-					isDir = ri.IsDir()
-				}
-			}
+		if path != "" {
+			fmt.Println(path)
 		}
+		return
+	}
 
-		if filesOnly && isDir {
-			continue
+	if tree {
+		if err := printTree(target, showAll, dirsOnly, maxLevel, excludes); err != nil {
+			fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+			os.Exit(1)
 		}
+		return
+	}
 
-		ext := ""
-		if !isDir {
-			ext = strings.TrimPrefix(filepath.Ext(name), ".")
-		}
+	items, err := listDir(target, showAll, filesOnly)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errStyle.Render("  error: "+err.Error()))
+		os.Exit(1)
+	}
 
-		items = append(items, entry{
-			name:  name,
-			isDir: isDir,
-			isSym: isSym,
-			size:  info.Size(),
-			dot:   isDot,
-			ext:   ext,
-		})
+	if sortKey != "" || reverse {
+		sortEntries(items, sortKey, reverse)
 	}
+	showMtime := longFormat || sortKey == "mtime"
 
-	// Sort: dirs first, then files, alphabetical within each
-	sort.Slice(items, func(i, j int) bool {
-		if items[i].isDir != items[j].isDir {
-			return items[i].isDir
-		}
-		return strings.ToLower(items[i].name) < strings.ToLower(items[j].name)
-	})
+	// Every normal invocation computes directory sizes through the on-disk
+	// index. Each listed directory is still walked in full and every entry
+	// beneath it is stat'ed; a file's cached record is reused only when its
+	// mtime is unchanged.
+	dirSizes, _ := indexDirSizes(target, items)
+
+	var iconCfg iconConfig
+	if showIcons {
+		iconCfg, _ = loadIconConfig()
+	}
+
+	var gitMap map[string]gitStatus
+	var gitRoot string
+	if showGit {
+		gitMap, gitRoot, _ = gitStatuses(target)
+	}
+	absTarget, _ := filepath.Abs(target)
 
 	if len(items) == 0 {
 		fmt.Println(countStyle.Render("  empty"))
@@ -152,17 +257,35 @@ func main() {
 
 	// Header
 	hType := headerStyle.Render(pad("TYPE", 4))
+	hIcon := headerStyle.Render(pad("", 2))
+	hGit := headerStyle.Render(pad("GIT", 3))
 	hName := headerStyle.Render(pad("NAME", maxName))
 	hExt := headerStyle.Render(pad("EXT", maxExt))
 	hSize := headerStyle.Render(padLeft("SIZE", 7))
+	hMtime := headerStyle.Render(pad("MTIME", mtimeWidth))
 	sep := sepStyle.Render("  ")
 
 	fmt.Println()
-	fmt.Println("  " + hType + sep + hName + sep + hExt + sep + hSize)
-	fmt.Println("  " + sepStyle.Render(strings.Repeat("─", 4)) + sep +
-		sepStyle.Render(strings.Repeat("─", maxName)) + sep +
+	headerLine := "  " + hType
+	ruleLine := "  " + sepStyle.Render(strings.Repeat("─", 4))
+	if showIcons {
+		headerLine += sep + hIcon
+		ruleLine += sep + sepStyle.Render(strings.Repeat("─", 2))
+	}
+	if showGit {
+		headerLine += sep + hGit
+		ruleLine += sep + sepStyle.Render(strings.Repeat("─", 3))
+	}
+	headerLine += sep + hName + sep + hExt + sep + hSize
+	ruleLine += sep + sepStyle.Render(strings.Repeat("─", maxName)) + sep +
 		sepStyle.Render(strings.Repeat("─", maxExt)) + sep +
-		sepStyle.Render(strings.Repeat("─", 7)))
+		sepStyle.Render(strings.Repeat("─", 7))
+	if showMtime {
+		headerLine += sep + hMtime
+		ruleLine += sep + sepStyle.Render(strings.Repeat("─", mtimeWidth))
+	}
+	fmt.Println(headerLine)
+	fmt.Println(ruleLine)
 
 	dirCount := 0
 	fileCount := 0
@@ -181,9 +304,25 @@ func main() {
 		}
 		tCol := pad(tag, 4)
 
+		// Git status
+		status := gitNone
+		if showGit {
+			if rel, err := filepath.Rel(gitRoot, filepath.Join(absTarget, it.name)); err == nil {
+				status = gitMap[filepath.ToSlash(rel)]
+			}
+		}
+
 		// Name
 		nameStr := pad(it.name, maxName)
 		switch {
+		case status == gitUntracked:
+			nameStr = gitUntrackedStyle.Render(nameStr)
+		case status == gitModified:
+			nameStr = gitModifiedStyle.Render(nameStr)
+		case status == gitStaged:
+			nameStr = gitStagedStyle.Render(nameStr)
+		case status == gitIgnored:
+			nameStr = gitIgnoredStyle.Render(nameStr)
 		case it.isSym:
 			nameStr = symNameStyle.Render(nameStr)
 		case it.isDir && it.dot:
@@ -207,13 +346,29 @@ func main() {
 		// Size
 		var sizeStr string
 		if it.isDir {
-			sizeStr = sizeDashStyle.Render(padLeft("—", 7))
+			if sz, ok := dirSizes[it.name]; ok {
+				num, unit := humanSizeParts(sz)
+				sizeStr = sizeNumStyle.Render(padLeft(num, 5)) + sizeUnitStyle.Render(padLeft(unit, 2))
+			} else {
+				sizeStr = sizeDashStyle.Render(padLeft("—", 7))
+			}
 		} else {
 			num, unit := humanSizeParts(it.size)
 			sizeStr = sizeNumStyle.Render(padLeft(num, 5)) + sizeUnitStyle.Render(padLeft(unit, 2))
 		}
 
-		fmt.Println("  " + tCol + sep + nameStr + sep + extStr + sep + sizeStr)
+		line := "  " + tCol
+		if showIcons {
+			line += sep + pad(iconCfg.iconFor(it), 2)
+		}
+		if showGit {
+			line += sep + pad(status.glyph(), 3)
+		}
+		line += sep + nameStr + sep + extStr + sep + sizeStr
+		if showMtime {
+			line += sep + mtimeStyle.Render(pad(formatMtime(it.mtime), mtimeWidth))
+		}
+		fmt.Println(line)
 	}
 
 	// Footer
@@ -235,18 +390,133 @@ func main() {
 	fmt.Println()
 }
 
+// listDir reads path and returns its visible entries sorted directories
+// first, then alphabetically within each group. Dot-files are skipped
+// unless showAll, and directories are skipped when filesOnly. Symlinks
+// are resolved to decide whether they behave like a directory.
+func listDir(path string, showAll, filesOnly bool) ([]entry, error) {
+	raw, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []entry
+	for _, e := range raw {
+		name := e.Name()
+		isDot := strings.HasPrefix(name, ".")
+
+		if isDot && !showAll {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		isDir := e.IsDir()
+		isSym := e.Type()&os.ModeSymlink != 0
+
+		if isSym {
+			resolved, err := filepath.EvalSymlinks(filepath.Join(path, name))
+			if err == nil {
+				ri, err := os.Stat(resolved)
+				if err == nil {
+					isDir = ri.IsDir()
+				}
+			}
+		}
+
+		if filesOnly && isDir {
+			continue
+		}
+
+		ext := ""
+		if !isDir {
+			ext = strings.TrimPrefix(filepath.Ext(name), ".")
+		}
+
+		items = append(items, entry{
+			name:  name,
+			isDir: isDir,
+			isSym: isSym,
+			size:  info.Size(),
+			dot:   isDot,
+			ext:   ext,
+			mtime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].isDir != items[j].isDir {
+			return items[i].isDir
+		}
+		return strings.ToLower(items[i].name) < strings.ToLower(items[j].name)
+	})
+
+	return items, nil
+}
+
+// sortComparators holds a "less" function per -S/--sort key. size and
+// mtime sort largest/newest first, matching coreutils ls -S/-t; name,
+// ext and type sort ascending.
+var sortComparators = map[string]func(a, b entry) bool{
+	"name": func(a, b entry) bool {
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	},
+	"size": func(a, b entry) bool {
+		return a.size > b.size
+	},
+	"ext": func(a, b entry) bool {
+		if a.ext != b.ext {
+			return strings.ToLower(a.ext) < strings.ToLower(b.ext)
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	},
+	"mtime": func(a, b entry) bool {
+		return a.mtime.After(b.mtime)
+	},
+	"type": func(a, b entry) bool {
+		if a.isDir != b.isDir {
+			return a.isDir
+		}
+		if a.isSym != b.isSym {
+			return a.isSym
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	},
+}
+
+// sortEntries reorders items in place by key (falling back to "name" for
+// an unknown or empty key), optionally reversing the result.
+func sortEntries(items []entry, key string, reverse bool) {
+	cmp, ok := sortComparators[key]
+	if !ok {
+		cmp = sortComparators["name"]
+	}
+	sort.Slice(items, func(i, j int) bool {
+		less := cmp(items[i], items[j])
+		if reverse {
+			return !less
+		}
+		return less
+	})
+}
+
 func pad(s string, width int) string {
-	if len(s) >= width {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
 		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return s + strings.Repeat(" ", width-n)
 }
 
 func padLeft(s string, width int) string {
-	if len(s) >= width {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
 		return s
 	}
-	return strings.Repeat(" ", width-len(s)) + s
+	return strings.Repeat(" ", width-n) + s
 }
 
 func humanSizeParts(b int64) (string, string) {
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// printTree renders target and its descendants as an ASCII tree, honoring
+// a max depth (maxLevel < 0 means unlimited), a dirs-only filter, and a
+// set of gitignore-style exclude patterns matched against each entry's
+// base name.
+func printTree(target string, showAll, dirsOnly bool, maxLevel int, excludes []string) error {
+	items, err := listDir(target, showAll, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("  " + headerStyle.Render(target))
+
+	root, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		root = target
+	}
+
+	dirCount, fileCount := 0, 0
+	walkTree(target, items, "", showAll, dirsOnly, maxLevel, 1, excludes, []string{root}, &dirCount, &fileCount)
+
+	fmt.Println()
+	fmt.Println("  " + countStyle.Render(treeSummary(dirCount, fileCount)))
+	fmt.Println()
+	return nil
+}
+
+// walkTree prints items (already listed for the current directory) and
+// recurses into subdirectories, tracking dirCount/fileCount across the
+// whole walk. visited holds the real (symlink-resolved) path of every
+// ancestor on the current branch, so a symlink that loops back to one of
+// them is reported but not descended into.
+func walkTree(dir string, items []entry, prefix string, showAll, dirsOnly bool, maxLevel, level int, excludes []string, visited []string, dirCount, fileCount *int) {
+	filtered := items[:0:0]
+	for _, it := range items {
+		if dirsOnly && !it.isDir {
+			continue
+		}
+		if matchesAny(it.name, excludes) {
+			continue
+		}
+		filtered = append(filtered, it)
+	}
+
+	for i, it := range filtered {
+		last := i == len(filtered)-1
+		connector := "├── "
+		childPrefix := prefix + "│   "
+		if last {
+			connector = "└── "
+			childPrefix = prefix + "    "
+		}
+
+		name := renderTreeName(it)
+		fmt.Println("  " + prefix + sepStyle.Render(connector) + name)
+
+		if it.isDir {
+			*dirCount++
+		} else {
+			*fileCount++
+		}
+
+		if !it.isDir {
+			continue
+		}
+		if maxLevel >= 0 && level >= maxLevel {
+			continue
+		}
+
+		childPath := filepath.Join(dir, it.name)
+		realChild, err := filepath.EvalSymlinks(childPath)
+		if err != nil {
+			realChild = childPath
+		}
+		if contains(visited, realChild) {
+			continue
+		}
+
+		children, err := listDir(childPath, showAll, false)
+		if err != nil {
+			continue
+		}
+		walkTree(childPath, children, childPrefix, showAll, dirsOnly, maxLevel, level+1, excludes, append(visited, realChild), dirCount, fileCount)
+	}
+}
+
+func renderTreeName(it entry) string {
+	switch {
+	case it.isSym:
+		return symNameStyle.Render(it.name)
+	case it.isDir && it.dot:
+		return dotNameStyle.Render(it.name)
+	case it.isDir:
+		return dirNameStyle.Render(it.name)
+	case it.dot:
+		return dotNameStyle.Render(it.name)
+	default:
+		return fileNameStyle.Render(it.name)
+	}
+}
+
+func contains(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether name matches any of the gitignore-style
+// exclude patterns (glob syntax, e.g. "*.log", "node_modules").
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, err := filepath.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func treeSummary(dirCount, fileCount int) string {
+	dirs := fmt.Sprintf("%d directories", dirCount)
+	if dirCount == 1 {
+		dirs = "1 directory"
+	}
+	files := fmt.Sprintf("%d files", fileCount)
+	if fileCount == 1 {
+		files = "1 file"
+	}
+	return dirs + ", " + files
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Selected row
+var cursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#0a0a0a")).Background(lipgloss.Color("#00ff66")).Bold(true)
+
+// runInteractive launches the Bubble Tea browser rooted at target and
+// returns the path the user exited on (via "q"), or "" if they aborted
+// with ctrl+c.
+func runInteractive(target string, showAll, filesOnly bool) (string, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+
+	m := &tuiModel{cwd: abs, showAll: showAll, filesOnly: filesOnly}
+	if err := m.load(); err != nil {
+		return "", err
+	}
+
+	// Render to stderr: runInteractive's contract is that the final path
+	// is the only thing ls ever writes to stdout, so callers can do
+	// cd "$(ls -i)" and get a clean path instead of the whole rendered
+	// frame glued onto it.
+	p := tea.NewProgram(m, tea.WithOutput(os.Stderr))
+	final, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	fm := final.(*tuiModel)
+	if fm.aborted {
+		return "", nil
+	}
+	return fm.finalPath, nil
+}
+
+type tuiModel struct {
+	cwd       string
+	showAll   bool
+	filesOnly bool
+
+	items  []entry
+	filter []int // indices into items; nil means "no filter active"
+	query  string
+	typing bool
+	cursor int
+
+	finalPath string
+	aborted   bool
+	err       error
+}
+
+func (m *tuiModel) Init() tea.Cmd { return nil }
+
+// load re-reads m.cwd into m.items, clearing any active filter and
+// resetting the cursor to the top of the listing.
+func (m *tuiModel) load() error {
+	items, err := listDir(m.cwd, m.showAll, m.filesOnly)
+	if err != nil {
+		return err
+	}
+	m.items = items
+	m.filter = nil
+	m.query = ""
+	m.typing = false
+	m.cursor = 0
+	return nil
+}
+
+// visible returns the indices into m.items currently shown, honoring an
+// active fuzzy filter.
+func (m *tuiModel) visible() []int {
+	if m.filter != nil {
+		return m.filter
+	}
+	idx := make([]int, len(m.items))
+	for i := range m.items {
+		idx[i] = i
+	}
+	return idx
+}
+
+func (m *tuiModel) applyFilter() {
+	if m.query == "" {
+		m.filter = nil
+		m.cursor = 0
+		return
+	}
+	// Fuzzy match: the query's characters must appear in the name in
+	// order, not necessarily next to each other.
+	q := []rune(strings.ToLower(m.query))
+	var matches []int
+	for i, it := range m.items {
+		j := 0
+		for _, r := range strings.ToLower(it.name) {
+			if j < len(q) && r == q[j] {
+				j++
+			}
+		}
+		if j == len(q) {
+			matches = append(matches, i)
+		}
+	}
+	m.filter = matches
+	m.cursor = 0
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.typing {
+		switch keyMsg.Type {
+		case tea.KeyEsc:
+			m.typing = false
+			m.query = ""
+			m.filter = nil
+			m.cursor = 0
+		case tea.KeyEnter:
+			m.typing = false
+		case tea.KeyBackspace:
+			if len(m.query) > 0 {
+				m.query = m.query[:len(m.query)-1]
+				m.applyFilter()
+			}
+		default:
+			if keyMsg.Type == tea.KeyRunes {
+				m.query += string(keyMsg.Runes)
+				m.applyFilter()
+			}
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "q", "ctrl+c":
+		vis := m.visible()
+		if len(vis) > 0 {
+			m.finalPath = filepath.Join(m.cwd, m.items[vis[m.cursor]].name)
+		} else {
+			m.finalPath = m.cwd
+		}
+		m.aborted = keyMsg.String() == "ctrl+c"
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visible())-1 {
+			m.cursor++
+		}
+	case "enter":
+		vis := m.visible()
+		if len(vis) == 0 {
+			return m, nil
+		}
+		it := m.items[vis[m.cursor]]
+		next := filepath.Join(m.cwd, it.name)
+		if it.isSym {
+			if resolved, err := filepath.EvalSymlinks(next); err == nil {
+				if info, err := os.Stat(resolved); err == nil && info.IsDir() {
+					next = resolved
+				} else {
+					return m, nil
+				}
+			} else {
+				return m, nil
+			}
+		} else if !it.isDir {
+			return m, nil
+		}
+		prev := m.cwd
+		m.cwd = next
+		if err := m.load(); err != nil {
+			m.cwd = prev
+			m.err = err
+			_ = m.load()
+		}
+	case "backspace":
+		parent := filepath.Dir(m.cwd)
+		if parent != m.cwd {
+			prev := m.cwd
+			m.cwd = parent
+			if err := m.load(); err != nil {
+				m.cwd = prev
+				m.err = err
+				_ = m.load()
+			}
+		}
+	case "/":
+		m.typing = true
+		m.query = ""
+	}
+	return m, nil
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "  "+headerStyle.Render(m.cwd))
+	if m.typing || m.query != "" {
+		fmt.Fprintln(&b, "  "+sepStyle.Render("/")+m.query)
+	}
+	fmt.Fprintln(&b)
+
+	vis := m.visible()
+	if len(vis) == 0 {
+		fmt.Fprintln(&b, "  "+countStyle.Render("  no matches"))
+	}
+
+	for row, i := range vis {
+		it := m.items[i]
+		it.selected = row == m.cursor
+
+		tag := fileTag
+		if it.isDir {
+			tag = dirTag
+		}
+		if it.isSym {
+			tag = symTag
+		}
+
+		name := it.name
+		switch {
+		case it.isSym:
+			name = symNameStyle.Render(name)
+		case it.isDir && it.dot:
+			name = dotNameStyle.Render(name)
+		case it.isDir:
+			name = dirNameStyle.Render(name)
+		case it.dot:
+			name = dotNameStyle.Render(name)
+		default:
+			name = fileNameStyle.Render(name)
+		}
+
+		line := "  " + pad(tag, 4) + "  " + name
+		if it.selected {
+			line = cursorStyle.Render("> " + pad(tag, 4) + "  " + it.name)
+		}
+		fmt.Fprintln(&b, line)
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "  "+countStyle.Render("↑/↓ move · enter open · backspace up · / filter · q quit"))
+	return b.String()
+}